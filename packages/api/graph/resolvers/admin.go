@@ -0,0 +1,23 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/models"
+)
+
+// AccessibleWorkspaces returns every workspace targetUserID can access,
+// owned or shared, annotated with their role. It is restricted to
+// callers with the admin claim so support staff can enumerate a user's
+// workspaces without impersonating them. The generated Query resolver
+// delegates here.
+func (r *Resolver) AccessibleWorkspaces(ctx context.Context, targetUserID string) ([]models.WorkspaceAccess, error) {
+	if !auth.IsAdmin(ctx) {
+		return nil, errors.New("accessibleWorkspaces: admin privileges required")
+	}
+
+	callerID, _ := auth.UserIDFromContext(ctx)
+	return r.Repo.Workspace.GetAccessibleWorkspaces(ctx, targetUserID, callerID)
+}
@@ -0,0 +1,115 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/crdt"
+	"github.com/chirag3003/collab-draw-backend/internal/repository"
+)
+
+// crdtFlushInterval is how often a dirty project Doc is compacted and
+// persisted back to Mongo, per the ~2s debounce called for by the CRDT
+// migration.
+const crdtFlushInterval = 2 * time.Second
+
+// projectPersister adapts repository.ProjectRepository to crdt.Persister.
+type projectPersister struct {
+	project repository.ProjectRepository
+}
+
+func (p projectPersister) PersistElements(ctx context.Context, projectID string, elementsBase64 string) error {
+	return p.project.PersistElements(ctx, projectID, elementsBase64)
+}
+
+func newCRDTStore(project repository.ProjectRepository) *crdt.Store {
+	return crdt.NewStore(projectPersister{project: project}, crdtFlushInterval)
+}
+
+// StartBackgroundWork launches the CRDT store's debounced flusher. It
+// blocks until ctx is cancelled, so server.go should call it in its own
+// goroutine right after constructing the Resolver.
+func (r *Resolver) StartBackgroundWork(ctx context.Context) {
+	r.CRDT.Run(ctx)
+}
+
+// SubscribeToProjectUpdates resolves the subscribeToProjectUpdates
+// subscription. clientId is generated by the caller and echoed back via
+// PushProjectUpdate so the server can skip re-delivering a client's own
+// writes to itself. The generated Subscription resolver delegates here.
+func (r *Resolver) SubscribeToProjectUpdates(ctx context.Context, projectID string, clientID string) (<-chan []byte, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errors.New("subscribeToProjectUpdates: authentication required")
+	}
+
+	project, err := r.Repo.Project.GetProjectByID(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("subscribeToProjectUpdates: project not found or access denied")
+	}
+
+	ch := make(chan []byte, 8)
+	r.subscribeToProjectUpdates(projectID, clientID, ch)
+
+	go func() {
+		<-ctx.Done()
+		r.unsubscribeFromProjectUpdates(projectID, clientID)
+	}()
+
+	return ch, nil
+}
+
+// PushProjectUpdate resolves the pushProjectUpdate mutation: it merges
+// update into projectID's in-memory Doc and rebroadcasts it to every
+// other subscriber. The generated Mutation resolver delegates here.
+func (r *Resolver) PushProjectUpdate(ctx context.Context, projectID string, clientID string, update []byte) (bool, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return false, errors.New("pushProjectUpdate: authentication required")
+	}
+
+	project, err := r.Repo.Project.GetProjectByID(ctx, projectID, userID)
+	if err != nil {
+		return false, err
+	}
+	if project == nil {
+		return false, errors.New("pushProjectUpdate: project not found or access denied")
+	}
+
+	r.ApplyProjectUpdate(projectID, project.Elements, update, clientID)
+	return true, nil
+}
+
+// SubscribeToAwareness resolves the subscribeToAwareness subscription.
+// The generated Subscription resolver delegates here.
+func (r *Resolver) SubscribeToAwareness(ctx context.Context, projectID string, clientID string) (<-chan []byte, error) {
+	if _, ok := auth.UserIDFromContext(ctx); !ok {
+		return nil, errors.New("subscribeToAwareness: authentication required")
+	}
+
+	ch := make(chan []byte, 8)
+	r.subscribeToAwareness(projectID, clientID, ch)
+
+	go func() {
+		<-ctx.Done()
+		r.unsubscribeFromAwareness(projectID, clientID)
+	}()
+
+	return ch, nil
+}
+
+// PushAwareness resolves the pushAwareness mutation. The generated
+// Mutation resolver delegates here.
+func (r *Resolver) PushAwareness(ctx context.Context, projectID string, clientID string, payload []byte) (bool, error) {
+	if _, ok := auth.UserIDFromContext(ctx); !ok {
+		return false, errors.New("pushAwareness: authentication required")
+	}
+
+	r.BroadcastAwareness(projectID, payload, clientID)
+	return true, nil
+}
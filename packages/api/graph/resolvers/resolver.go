@@ -3,11 +3,19 @@ package resolvers
 //go:generate go run github.com/99designs/gqlgen generate
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/chirag3003/collab-draw-backend/graph/model"
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/crdt"
+	"github.com/chirag3003/collab-draw-backend/internal/metrics"
 	"github.com/chirag3003/collab-draw-backend/internal/repository"
 )
 
@@ -15,21 +23,95 @@ import (
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
+const statsBroadcastInterval = 10 * time.Second
+
 type ProjectSubscriber struct {
 	sockedID string
 	channel  chan *model.ProjectSubscription
 }
 
+// ProjectUpdateSubscriber is a peer connected via the CRDT update
+// protocol: it exchanges raw update frames instead of a full
+// ProjectSubscription payload. The same shape backs both the persisted
+// document channel and the ephemeral awareness channel below.
+type ProjectUpdateSubscriber struct {
+	sockedID string
+	channel  chan []byte
+}
+
+// ProjectStats tracks a single project's live realtime metrics:
+// connected editors, and the messages/bytes broadcastProjectUpdate and
+// broadcastCRDTUpdate have sent, dropped (a subscriber's channel was full or closed), or pushed
+// out. All fields are updated via sync/atomic so broadcasters on
+// different goroutines never race.
+type ProjectStats struct {
+	Subscribers     atomic.Int64
+	MessagesSent    atomic.Uint64
+	MessagesDropped atomic.Uint64
+	BytesSent       atomic.Uint64
+}
+
+// Snapshot returns a point-in-time, JSON/GraphQL-friendly view of s,
+// used both for the projectStats query and the periodic stats frame
+// pushed down subscription channels.
+func (s *ProjectStats) Snapshot(projectID string) *ProjectStatsSnapshot {
+	return &ProjectStatsSnapshot{
+		ProjectID:       projectID,
+		Subscribers:     s.Subscribers.Load(),
+		MessagesSent:    s.MessagesSent.Load(),
+		MessagesDropped: s.MessagesDropped.Load(),
+		BytesSent:       s.BytesSent.Load(),
+	}
+}
+
+// ProjectStatsSnapshot is an immutable copy of a ProjectStats reading.
+type ProjectStatsSnapshot struct {
+	ProjectID       string
+	Subscribers     int64
+	MessagesSent    uint64
+	MessagesDropped uint64
+	BytesSent       uint64
+}
+
 type Resolver struct {
 	Repo               *repository.Repository
 	projectSubscribers map[string][]ProjectSubscriber
 	subscribersMutex   sync.RWMutex
+
+	projectStats map[string]*ProjectStats
+	statsMutex   sync.RWMutex
+
+	statsSubscribers      map[string][]chan *ProjectStatsSnapshot
+	statsSubscribersMutex sync.RWMutex
+
+	// statsBroadcasters holds the stop channel for the running
+	// runStatsBroadcaster goroutine of each project with at least one
+	// live editor, so it can be reaped deterministically instead of
+	// polling projectSubscribers on every tick.
+	statsBroadcasters      map[string]chan struct{}
+	statsBroadcastersMutex sync.Mutex
+
+	// CRDT holds the in-memory per-project Yjs-style update log and
+	// debounces persisting it back to Mongo. See internal/crdt.
+	CRDT *crdt.Store
+
+	crdtSubscribers      map[string][]ProjectUpdateSubscriber
+	crdtSubscribersMutex sync.RWMutex
+
+	awarenessSubscribers      map[string][]ProjectUpdateSubscriber
+	awarenessSubscribersMutex sync.RWMutex
 }
 
 func NewResolver(repo *repository.Repository) *Resolver {
 	return &Resolver{
-		Repo:               repo,
-		projectSubscribers: make(map[string][]ProjectSubscriber),
+		Repo:                 repo,
+		projectSubscribers:   make(map[string][]ProjectSubscriber),
+		projectStats:         make(map[string]*ProjectStats),
+		statsSubscribers:     make(map[string][]chan *ProjectStatsSnapshot),
+		statsBroadcasters:    make(map[string]chan struct{}),
+		CRDT:                 newCRDTStore(repo.Project),
+		crdtSubscribers:      make(map[string][]ProjectUpdateSubscriber),
+		awarenessSubscribers: make(map[string][]ProjectUpdateSubscriber),
 	}
 }
 
@@ -44,15 +126,66 @@ func generateRandom8DigitString() string {
 	return fmt.Sprintf("%d", randomNumber)
 }
 
+// statsFor returns the ProjectStats for projectID, creating it on first
+// use.
+func (r *Resolver) statsFor(projectID string) *ProjectStats {
+	r.statsMutex.RLock()
+	stats, ok := r.projectStats[projectID]
+	r.statsMutex.RUnlock()
+	if ok {
+		return stats
+	}
+
+	r.statsMutex.Lock()
+	defer r.statsMutex.Unlock()
+	if stats, ok = r.projectStats[projectID]; ok {
+		return stats
+	}
+	stats = &ProjectStats{}
+	r.projectStats[projectID] = stats
+	return stats
+}
+
+// ProjectStats returns the current metrics snapshot for projectID,
+// restricted to the project's owner. The generated Query resolver
+// delegates here.
+func (r *Resolver) ProjectStats(ctx context.Context, projectID string) (*ProjectStatsSnapshot, error) {
+	if err := r.requireProjectOwner(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("projectStats: %w", err)
+	}
+	return r.statsFor(projectID).Snapshot(projectID), nil
+}
+
+// requireProjectOwner looks up projectID and rejects the request unless
+// the authenticated caller is its owner.
+func (r *Resolver) requireProjectOwner(ctx context.Context, projectID string) error {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return errors.New("authentication required")
+	}
+
+	project, err := r.Repo.Project.GetProjectByID(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if project == nil || project.Owner != userID {
+		return errors.New("owner privileges required")
+	}
+	return nil
+}
+
 // Subscribe adds a subscriber for a specific project
 func (r *Resolver) subscribeToProject(projectID string, ch chan *model.ProjectSubscription) string {
 	r.subscribersMutex.Lock()
-	defer r.subscribersMutex.Unlock()
 	subscriber := ProjectSubscriber{
 		channel:  ch,
 		sockedID: generateRandom8DigitString(),
 	}
 	r.projectSubscribers[projectID] = append(r.projectSubscribers[projectID], subscriber)
+	r.subscribersMutex.Unlock()
+
+	r.noteSubscriberJoined(projectID)
+
 	return subscriber.sockedID
 }
 
@@ -66,21 +199,65 @@ func (r *Resolver) unsubscribeFromProject(projectID string, socketID string) {
 		if subscriber.sockedID == socketID {
 			r.projectSubscribers[projectID] = append(subscribers[:i], subscribers[i+1:]...)
 			close(subscriber.channel)
+			r.noteSubscriberLeft(projectID)
 			break
 		}
 	}
 
-	// Clean up empty subscriber lists
 	if len(r.projectSubscribers[projectID]) == 0 {
 		delete(r.projectSubscribers, projectID)
 	}
 }
 
+// noteSubscriberJoined records a new live subscriber for projectID, via
+// either the legacy protocol (subscribeToProject) or the CRDT protocol
+// (subscribeToProjectUpdates), and starts the project's stats
+// broadcaster the moment it's the first subscriber across both.
+func (r *Resolver) noteSubscriberJoined(projectID string) {
+	metrics.ProjectSubscribers.WithLabelValues(projectID).Inc()
+
+	// The count and the broadcaster map change under one lock so a
+	// concurrent noteSubscriberLeft can't interleave between them.
+	r.statsBroadcastersMutex.Lock()
+	defer r.statsBroadcastersMutex.Unlock()
+	if r.statsFor(projectID).Subscribers.Add(1) != 1 {
+		return
+	}
+
+	stop := make(chan struct{})
+	r.statsBroadcasters[projectID] = stop
+	go r.runStatsBroadcaster(projectID, stop)
+}
+
+// noteSubscriberLeft is the counterpart to noteSubscriberJoined: it
+// stops the broadcaster once the last subscriber across both protocols
+// has disconnected.
+func (r *Resolver) noteSubscriberLeft(projectID string) {
+	metrics.ProjectSubscribers.WithLabelValues(projectID).Dec()
+
+	r.statsBroadcastersMutex.Lock()
+	defer r.statsBroadcastersMutex.Unlock()
+	if r.statsFor(projectID).Subscribers.Add(-1) != 0 {
+		return
+	}
+
+	if stop, ok := r.statsBroadcasters[projectID]; ok {
+		close(stop)
+		delete(r.statsBroadcasters, projectID)
+	}
+}
+
 // Broadcast sends a project update to all subscribers
 func (r *Resolver) broadcastProjectUpdate(projectID string, project *model.ProjectSubscription, fromID string) {
 	r.subscribersMutex.RLock()
 	defer r.subscribersMutex.RUnlock()
 
+	stats := r.statsFor(projectID)
+	payloadSize := uint64(0)
+	if raw, err := json.Marshal(project); err == nil {
+		payloadSize = uint64(len(raw))
+	}
+
 	if subscribers, ok := r.projectSubscribers[projectID]; ok {
 		for _, subscriber := range subscribers {
 			if subscriber.sockedID == fromID {
@@ -89,9 +266,210 @@ func (r *Resolver) broadcastProjectUpdate(projectID string, project *model.Proje
 			project.SocketID = subscriber.sockedID
 			select {
 			case subscriber.channel <- project:
+				stats.MessagesSent.Add(1)
+				stats.BytesSent.Add(payloadSize)
+				metrics.BroadcastMessagesTotal.WithLabelValues(projectID).Inc()
+				metrics.BroadcastBytesTotal.WithLabelValues(projectID).Add(float64(payloadSize))
 			default:
 				// Channel is full or closed, skip
+				stats.MessagesDropped.Add(1)
+				metrics.BroadcastDroppedTotal.WithLabelValues(projectID).Inc()
 			}
 		}
 	}
 }
+
+// SubscribeToProjectStats resolves the projectStatsStream subscription,
+// restricted to the project owner: it streams an aggregated
+// ProjectStats snapshot every statsBroadcastInterval for as long as the
+// project has at least one live editor connected. The generated
+// Subscription resolver delegates here.
+func (r *Resolver) SubscribeToProjectStats(ctx context.Context, projectID string) (<-chan *ProjectStatsSnapshot, error) {
+	if err := r.requireProjectOwner(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("projectStatsStream: %w", err)
+	}
+
+	ch := make(chan *ProjectStatsSnapshot, 4)
+	r.SubscribeToStats(projectID, ch)
+
+	go func() {
+		<-ctx.Done()
+		r.UnsubscribeFromStats(projectID, ch)
+	}()
+
+	return ch, nil
+}
+
+// SubscribeToStats registers ch to receive the periodic aggregated
+// stats frame for projectID, so a client's subscription can display
+// live presence counts alongside board updates. Callers must eventually
+// call UnsubscribeFromStats with the same channel.
+func (r *Resolver) SubscribeToStats(projectID string, ch chan *ProjectStatsSnapshot) {
+	r.statsSubscribersMutex.Lock()
+	defer r.statsSubscribersMutex.Unlock()
+	r.statsSubscribers[projectID] = append(r.statsSubscribers[projectID], ch)
+}
+
+// UnsubscribeFromStats removes ch from projectID's stats fan-out.
+func (r *Resolver) UnsubscribeFromStats(projectID string, ch chan *ProjectStatsSnapshot) {
+	r.statsSubscribersMutex.Lock()
+	defer r.statsSubscribersMutex.Unlock()
+
+	subscribers := r.statsSubscribers[projectID]
+	for i, s := range subscribers {
+		if s == ch {
+			r.statsSubscribers[projectID] = append(subscribers[:i], subscribers[i+1:]...)
+			break
+		}
+	}
+	if len(r.statsSubscribers[projectID]) == 0 {
+		delete(r.statsSubscribers, projectID)
+	}
+}
+
+// runStatsBroadcaster pushes an aggregated stats snapshot to projectID's
+// stats subscribers every statsBroadcastInterval, until stop is closed
+// by noteSubscriberLeft when projectID's last editor disconnects.
+// Reaping on stop rather than by polling projectSubscribers on each
+// tick means a subscriber count that flaps 1->0->1 faster than the tick
+// can't leave two broadcasters running for the same project.
+func (r *Resolver) runStatsBroadcaster(projectID string, stop <-chan struct{}) {
+	ticker := time.NewTicker(statsBroadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			snapshot := r.statsFor(projectID).Snapshot(projectID)
+
+			r.statsSubscribersMutex.RLock()
+			for _, ch := range r.statsSubscribers[projectID] {
+				select {
+				case ch <- snapshot:
+				default:
+				}
+			}
+			r.statsSubscribersMutex.RUnlock()
+		}
+	}
+}
+
+// subscribeToProjectUpdates registers ch for the CRDT update protocol
+// under clientID (chosen by the caller, see SubscribeToProjectUpdates),
+// mirroring subscribeToProject's presence-stats bookkeeping but for raw
+// update frames rather than full subscription payloads.
+func (r *Resolver) subscribeToProjectUpdates(projectID string, clientID string, ch chan []byte) {
+	r.crdtSubscribersMutex.Lock()
+	r.crdtSubscribers[projectID] = append(r.crdtSubscribers[projectID], ProjectUpdateSubscriber{
+		channel:  ch,
+		sockedID: clientID,
+	})
+	r.crdtSubscribersMutex.Unlock()
+
+	r.noteSubscriberJoined(projectID)
+}
+
+// unsubscribeFromProjectUpdates is the CRDT-protocol counterpart to
+// unsubscribeFromProject.
+func (r *Resolver) unsubscribeFromProjectUpdates(projectID string, socketID string) {
+	r.crdtSubscribersMutex.Lock()
+	defer r.crdtSubscribersMutex.Unlock()
+
+	subscribers := r.crdtSubscribers[projectID]
+	for i, subscriber := range subscribers {
+		if subscriber.sockedID == socketID {
+			r.crdtSubscribers[projectID] = append(subscribers[:i], subscribers[i+1:]...)
+			close(subscriber.channel)
+			r.noteSubscriberLeft(projectID)
+			break
+		}
+	}
+	if len(r.crdtSubscribers[projectID]) == 0 {
+		delete(r.crdtSubscribers, projectID)
+	}
+}
+
+// ApplyProjectUpdate merges a client's CRDT update frame into
+// projectID's in-memory Doc (seeding it from currentElements, legacy
+// JSON or a previously persisted base64 state, on first use) and
+// rebroadcasts the raw frame to every other subscriber. Persistence
+// happens asynchronously via r.CRDT's debounced flusher, not inline
+// here.
+func (r *Resolver) ApplyProjectUpdate(projectID string, currentElements string, update []byte, fromSocketID string) {
+	doc := r.CRDT.Get(projectID, currentElements)
+	doc.Apply(update)
+	r.broadcastCRDTUpdate(projectID, update, fromSocketID)
+}
+
+func (r *Resolver) broadcastCRDTUpdate(projectID string, update []byte, fromSocketID string) {
+	r.crdtSubscribersMutex.RLock()
+	defer r.crdtSubscribersMutex.RUnlock()
+
+	stats := r.statsFor(projectID)
+	for _, subscriber := range r.crdtSubscribers[projectID] {
+		if subscriber.sockedID == fromSocketID {
+			continue
+		}
+		select {
+		case subscriber.channel <- update:
+			stats.MessagesSent.Add(1)
+			stats.BytesSent.Add(uint64(len(update)))
+			metrics.BroadcastMessagesTotal.WithLabelValues(projectID).Inc()
+			metrics.BroadcastBytesTotal.WithLabelValues(projectID).Add(float64(len(update)))
+		default:
+			stats.MessagesDropped.Add(1)
+			metrics.BroadcastDroppedTotal.WithLabelValues(projectID).Inc()
+		}
+	}
+}
+
+// subscribeToAwareness registers ch for projectID's ephemeral
+// cursor/selection channel under clientID (see SubscribeToAwareness).
+// Awareness is broadcast only, never merged into a Doc or persisted.
+func (r *Resolver) subscribeToAwareness(projectID string, clientID string, ch chan []byte) {
+	r.awarenessSubscribersMutex.Lock()
+	defer r.awarenessSubscribersMutex.Unlock()
+
+	r.awarenessSubscribers[projectID] = append(r.awarenessSubscribers[projectID], ProjectUpdateSubscriber{
+		channel:  ch,
+		sockedID: clientID,
+	})
+}
+
+// unsubscribeFromAwareness is the awareness-channel counterpart to
+// subscribeToAwareness.
+func (r *Resolver) unsubscribeFromAwareness(projectID string, socketID string) {
+	r.awarenessSubscribersMutex.Lock()
+	defer r.awarenessSubscribersMutex.Unlock()
+
+	subscribers := r.awarenessSubscribers[projectID]
+	for i, subscriber := range subscribers {
+		if subscriber.sockedID == socketID {
+			r.awarenessSubscribers[projectID] = append(subscribers[:i], subscribers[i+1:]...)
+			close(subscriber.channel)
+			break
+		}
+	}
+	if len(r.awarenessSubscribers[projectID]) == 0 {
+		delete(r.awarenessSubscribers, projectID)
+	}
+}
+
+// BroadcastAwareness fans payload (a cursor/selection update, keyed on
+// fromSocketID) out to every other awareness subscriber of projectID.
+func (r *Resolver) BroadcastAwareness(projectID string, payload []byte, fromSocketID string) {
+	r.awarenessSubscribersMutex.RLock()
+	defer r.awarenessSubscribersMutex.RUnlock()
+
+	for _, subscriber := range r.awarenessSubscribers[projectID] {
+		if subscriber.sockedID == fromSocketID {
+			continue
+		}
+		select {
+		case subscriber.channel <- payload:
+		default:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/models"
+	"github.com/chirag3003/collab-draw-backend/internal/templates"
+)
+
+// Templates returns the curated template library clients choose from
+// when creating a new project. The generated Query resolver delegates
+// here.
+func (r *Resolver) Templates(ctx context.Context) ([]templates.Template, error) {
+	return r.Repo.Templates.List(ctx)
+}
+
+// ApplyTemplate seeds project.Elements from the named template. Called
+// by CreateProject when the caller passes a templateId, before the
+// project is persisted.
+func (r *Resolver) ApplyTemplate(ctx context.Context, project *models.Project, templateID string) error {
+	tpl, err := r.Repo.Templates.Get(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("applyTemplate: %w", err)
+	}
+	project.Elements = tpl.Elements
+	return nil
+}
+
+// CreateProject resolves the createProject mutation: it builds a new
+// project owned by the caller and, when templateId is set, seeds its
+// Elements from that template via ApplyTemplate before persisting it.
+// The generated Mutation resolver delegates here.
+func (r *Resolver) CreateProject(ctx context.Context, name string, description string, templateID *string) (*models.Project, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return nil, errors.New("createProject: authentication required")
+	}
+
+	project := &models.Project{
+		Name:        name,
+		Description: description,
+		Owner:       userID,
+		Members:     []string{userID},
+	}
+
+	if templateID != nil {
+		if err := r.ApplyTemplate(ctx, project, *templateID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.Repo.Project.CreateProject(ctx, project); err != nil {
+		return nil, fmt.Errorf("createProject: %w", err)
+	}
+	return project, nil
+}
@@ -0,0 +1,16 @@
+package auth
+
+import "context"
+
+// IsAdmin reports whether ctx carries verified Claims with the "admin"
+// role, regardless of which Provider verified the token. Used to gate
+// support-only endpoints, such as the cross-user workspace listing,
+// behind an explicit claim rather than any form of impersonation.
+func IsAdmin(ctx context.Context) bool {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return false
+	}
+	role, _ := claims.Extra["role"].(string)
+	return role == "admin"
+}
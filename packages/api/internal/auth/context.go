@@ -0,0 +1,26 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+// UserContextKey is the context key under which the verified Claims for
+// the current request are stored by Middleware and the WebSocket
+// InitFunc.
+const UserContextKey contextKey = "auth-claims"
+
+// ClaimsFromContext returns the verified Claims stored on ctx, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(UserContextKey).(*Claims)
+	return claims, ok
+}
+
+// UserIDFromContext is a convenience wrapper around ClaimsFromContext
+// for the common case of just needing the caller's user id.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	return claims.UserID, true
+}
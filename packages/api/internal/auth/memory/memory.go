@@ -0,0 +1,72 @@
+// Package memory implements auth.Provider entirely in-memory, so tests
+// and local development don't need real Clerk/OIDC credentials.
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+)
+
+// Provider is an in-memory auth.Provider. Tokens are just
+// "Bearer <userID>"; users are whatever's been registered with AddUser.
+type Provider struct {
+	mu    sync.RWMutex
+	users map[string]auth.User
+}
+
+// New returns an empty in-memory Provider. Call AddUser to seed it.
+func New() *Provider {
+	return &Provider{users: make(map[string]auth.User)}
+}
+
+// AddUser registers a user so it can be verified and looked up.
+func (p *Provider) AddUser(u auth.User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.users[u.ID] = u
+}
+
+func (p *Provider) VerifyToken(ctx context.Context, bearer string) (*auth.Claims, error) {
+	token := strings.TrimPrefix(bearer, "Bearer ")
+	if token == "" {
+		return nil, errors.New("memory: empty token")
+	}
+
+	p.mu.RLock()
+	u, ok := p.users[token]
+	p.mu.RUnlock()
+	if !ok {
+		return nil, errors.New("memory: unknown user token")
+	}
+	return &auth.Claims{UserID: u.ID, Email: u.Email}, nil
+}
+
+func (p *Provider) LookupUsers(ctx context.Context, ids []string) ([]auth.User, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	users := make([]auth.User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := p.users[id]; ok {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func (p *Provider) LookupByEmail(ctx context.Context, email string) ([]auth.User, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var users []auth.User
+	for _, u := range p.users {
+		if u.Email == email {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+)
+
+func TestProviderVerifyToken(t *testing.T) {
+	p := New()
+	p.AddUser(auth.User{ID: "user_1", Email: "a@example.com"})
+
+	claims, err := p.VerifyToken(context.Background(), "Bearer user_1")
+	if err != nil {
+		t.Fatalf("VerifyToken returned error: %v", err)
+	}
+	if claims.UserID != "user_1" || claims.Email != "a@example.com" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestProviderVerifyTokenUnknownUser(t *testing.T) {
+	p := New()
+	if _, err := p.VerifyToken(context.Background(), "Bearer nobody"); err == nil {
+		t.Fatal("expected error for unknown user token")
+	}
+}
+
+func TestProviderVerifyTokenEmptyToken(t *testing.T) {
+	p := New()
+	if _, err := p.VerifyToken(context.Background(), ""); err == nil {
+		t.Fatal("expected error for empty token")
+	}
+}
+
+func TestProviderLookupUsers(t *testing.T) {
+	p := New()
+	p.AddUser(auth.User{ID: "user_1", Email: "a@example.com"})
+	p.AddUser(auth.User{ID: "user_2", Email: "b@example.com"})
+
+	users, err := p.LookupUsers(context.Background(), []string{"user_1", "missing"})
+	if err != nil {
+		t.Fatalf("LookupUsers returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user_1" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+}
+
+func TestProviderLookupByEmail(t *testing.T) {
+	p := New()
+	p.AddUser(auth.User{ID: "user_1", Email: "a@example.com"})
+
+	users, err := p.LookupByEmail(context.Background(), "a@example.com")
+	if err != nil {
+		t.Fatalf("LookupByEmail returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user_1" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+}
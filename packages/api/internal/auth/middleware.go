@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// Middleware verifies the bearer token on each request with provider and
+// stores the resulting Claims in the request context under
+// UserContextKey. Requests without a valid token are passed through
+// unauthenticated; resolvers that require a caller check
+// ClaimsFromContext themselves.
+func Middleware(provider Provider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			bearer := r.Header.Get("Authorization")
+			if bearer != "" {
+				if claims, err := provider.VerifyToken(r.Context(), bearer); err == nil {
+					r = r.WithContext(context.WithValue(r.Context(), UserContextKey, claims))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
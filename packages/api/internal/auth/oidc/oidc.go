@@ -0,0 +1,63 @@
+// Package oidc implements auth.Provider against any standard OIDC
+// issuer (Dex, Keycloak, Auth0, ...), so self-hosters aren't forced onto
+// Clerk.
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+)
+
+// Provider verifies ID tokens issued by a single OIDC issuer. It only
+// verifies tokens; self-hosters are expected to manage their own user
+// directory, so LookupUsers and LookupByEmail are unsupported.
+type Provider struct {
+	verifier *gooidc.IDTokenVerifier
+}
+
+// New discovers issuerURL's OIDC configuration and returns a Provider
+// that verifies tokens issued for clientID.
+func New(ctx context.Context, issuerURL string, clientID string) (*Provider, error) {
+	p, err := gooidc.NewProvider(ctx, issuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: discover issuer: %w", err)
+	}
+	return &Provider{
+		verifier: p.Verifier(&gooidc.Config{ClientID: clientID}),
+	}, nil
+}
+
+func (p *Provider) VerifyToken(ctx context.Context, bearer string) (*auth.Claims, error) {
+	token := strings.TrimPrefix(bearer, "Bearer ")
+	idToken, err := p.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: verify token: %w", err)
+	}
+
+	var claims struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("oidc: decode claims: %w", err)
+	}
+
+	return &auth.Claims{
+		UserID: idToken.Subject,
+		Email:  claims.Email,
+		Extra:  map[string]interface{}{"role": claims.Role},
+	}, nil
+}
+
+func (p *Provider) LookupUsers(ctx context.Context, ids []string) ([]auth.User, error) {
+	return nil, fmt.Errorf("oidc: user lookup by id is not supported by the OIDC provider")
+}
+
+func (p *Provider) LookupByEmail(ctx context.Context, email string) ([]auth.User, error) {
+	return nil, fmt.Errorf("oidc: user lookup by email is not supported by the OIDC provider")
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+// Claims is the provider-agnostic result of verifying a bearer token.
+// Extra carries whatever provider-specific custom claims (roles,
+// metadata) don't have a first-class field above.
+type Claims struct {
+	UserID string
+	Email  string
+	Extra  map[string]interface{}
+}
+
+// User is a provider-agnostic user record, as returned by user lookups.
+type User struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	ImageURL  string
+}
+
+// Provider abstracts the identity backend so the rest of the app never
+// imports a specific auth SDK directly. Swap implementations (Clerk,
+// OIDC, an in-memory fake for tests) by changing what repository.Setup
+// wires into Repository.Auth.
+type Provider interface {
+	VerifyToken(ctx context.Context, bearer string) (*Claims, error)
+	LookupUsers(ctx context.Context, ids []string) ([]User, error)
+	LookupByEmail(ctx context.Context, email string) ([]User, error)
+}
@@ -0,0 +1,67 @@
+// Package crdt replaces collab-draw's last-writer-wins broadcast with a
+// CRDT-style update log, so two clients drawing on the same project
+// concurrently converge instead of clobbering each other on persist.
+//
+// Updates are treated as opaque byte frames (e.g. Yjs update frames from
+// the client); the server doesn't need to understand their contents to
+// merge them, only to keep them in the order they were applied. This
+// keeps the door open to swapping in a real Yjs port later without
+// touching the rest of the package.
+package crdt
+
+import "sync"
+
+// Doc is an in-memory CRDT document for a single project: an
+// append-only log of update frames, compacted on flush.
+type Doc struct {
+	mu      sync.Mutex
+	base    []byte   // compacted log as of the last flush (or the seed)
+	updates [][]byte // frames applied since then
+	dirty   bool
+}
+
+// NewDoc creates a document, optionally seeded from a previously
+// persisted (and already-decoded) state.
+func NewDoc(seed []byte) *Doc {
+	return &Doc{base: seed}
+}
+
+// Apply merges a client update into the document and marks it dirty for
+// the next flush.
+func (d *Doc) Apply(update []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.updates = append(d.updates, update)
+	d.dirty = true
+}
+
+// State returns the compacted update log representing the document's
+// full current content, ready to persist or send to a newly joining
+// client.
+func (d *Doc) State() []byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.compactLocked()
+}
+
+// compactLocked appends the merged pending frames to the compacted base;
+// since Merge is associative, this equals merging every frame in order.
+func (d *Doc) compactLocked() []byte {
+	out := append([]byte(nil), d.base...)
+	return append(out, mergeLocked(d.updates)...)
+}
+
+// FlushIfDirty returns the compacted state and clears the dirty flag, or
+// ok=false if nothing has changed since the last flush.
+func (d *Doc) FlushIfDirty() (state []byte, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.dirty {
+		return nil, false
+	}
+	state = d.compactLocked()
+	d.base = state
+	d.updates = nil
+	d.dirty = false
+	return state, true
+}
@@ -0,0 +1,56 @@
+package crdt
+
+import "testing"
+
+func TestNewDocSeed(t *testing.T) {
+	d := NewDoc([]byte("seed"))
+	if got := string(d.State()); got != "seed" {
+		t.Fatalf("State() = %q, want %q", got, "seed")
+	}
+}
+
+func TestNewDocNoSeed(t *testing.T) {
+	d := NewDoc(nil)
+	if got := d.State(); len(got) != 0 {
+		t.Fatalf("State() = %q, want empty", got)
+	}
+}
+
+func TestDocApplyMergesIntoState(t *testing.T) {
+	d := NewDoc(nil)
+	d.Apply([]byte("a"))
+	d.Apply([]byte("bb"))
+
+	got := Split(d.State())
+	want := [][]byte{[]byte("a"), []byte("bb")}
+	if len(got) != len(want) {
+		t.Fatalf("Split(State()) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if string(got[i]) != string(want[i]) {
+			t.Fatalf("Split(State())[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDocFlushIfDirty(t *testing.T) {
+	d := NewDoc(nil)
+
+	if _, ok := d.FlushIfDirty(); ok {
+		t.Fatal("FlushIfDirty() on a fresh Doc should report not dirty")
+	}
+
+	d.Apply([]byte("update"))
+
+	state, ok := d.FlushIfDirty()
+	if !ok {
+		t.Fatal("FlushIfDirty() after Apply should report dirty")
+	}
+	if got := Split(state); len(got) != 1 || string(got[0]) != "update" {
+		t.Fatalf("FlushIfDirty() state = %v, want [update]", got)
+	}
+
+	if _, ok := d.FlushIfDirty(); ok {
+		t.Fatal("FlushIfDirty() called twice in a row should report not dirty the second time")
+	}
+}
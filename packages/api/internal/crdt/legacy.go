@@ -0,0 +1,20 @@
+package crdt
+
+import "encoding/json"
+
+// LooksLegacy reports whether elements is the pre-CRDT representation:
+// a raw JSON string, as stored by every project created before this
+// migration, rather than a base64-encoded Y.Doc update log.
+func LooksLegacy(elements string) bool {
+	if elements == "" {
+		return false
+	}
+	return json.Valid([]byte(elements))
+}
+
+// FromLegacy wraps a legacy JSON elements string as the initial update
+// frame of a new Doc, so existing projects keep their content the first
+// time they're opened after the CRDT migration.
+func FromLegacy(elements string) *Doc {
+	return NewDoc(Merge([][]byte{[]byte(elements)}))
+}
@@ -0,0 +1,33 @@
+package crdt
+
+import "testing"
+
+func TestLooksLegacy(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements string
+		want     bool
+	}{
+		{"empty", "", false},
+		{"legacy json object", `{"elements":[]}`, true},
+		{"legacy json array", `[]`, true},
+		{"base64 state", "dXBkYXRl", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LooksLegacy(tc.elements); got != tc.want {
+				t.Errorf("LooksLegacy(%q) = %v, want %v", tc.elements, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromLegacyWrapsElementsAsInitialFrame(t *testing.T) {
+	d := FromLegacy(`{"a":1}`)
+
+	got := Split(d.State())
+	if len(got) != 1 || string(got[0]) != `{"a":1}` {
+		t.Fatalf("FromLegacy state = %v, want a single frame with the original JSON", got)
+	}
+}
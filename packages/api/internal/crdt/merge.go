@@ -0,0 +1,46 @@
+package crdt
+
+import "encoding/binary"
+
+// Merge concatenates a sequence of update frames into a single
+// length-prefixed blob, so the whole history can round-trip through a
+// single []byte without losing frame boundaries.
+//
+// This is an associative, order-preserving compaction placeholder until
+// a real Yjs update-merge (e.g. a Go Yjs port) is wired in; the exported
+// seam is Doc, not this function, so swapping the strategy later
+// doesn't change callers.
+func Merge(updates [][]byte) []byte {
+	return mergeLocked(updates)
+}
+
+func mergeLocked(updates [][]byte) []byte {
+	size := 0
+	for _, u := range updates {
+		size += 4 + len(u)
+	}
+
+	out := make([]byte, 0, size)
+	var lenBuf [4]byte
+	for _, u := range updates {
+		binary.BigEndian.PutUint32(lenBuf[:], uint32(len(u)))
+		out = append(out, lenBuf[:]...)
+		out = append(out, u...)
+	}
+	return out
+}
+
+// Split reverses Merge, returning the individual update frames.
+func Split(blob []byte) [][]byte {
+	var updates [][]byte
+	for len(blob) >= 4 {
+		n := binary.BigEndian.Uint32(blob[:4])
+		blob = blob[4:]
+		if uint32(len(blob)) < n {
+			break
+		}
+		updates = append(updates, blob[:n])
+		blob = blob[n:]
+	}
+	return updates
+}
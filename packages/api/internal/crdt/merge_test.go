@@ -0,0 +1,32 @@
+package crdt
+
+import "testing"
+
+func TestMergeSplitRoundTrip(t *testing.T) {
+	updates := [][]byte{[]byte("one"), []byte(""), []byte("three")}
+
+	got := Split(Merge(updates))
+	if len(got) != len(updates) {
+		t.Fatalf("Split(Merge(updates)) = %v, want %v", got, updates)
+	}
+	for i := range updates {
+		if string(got[i]) != string(updates[i]) {
+			t.Fatalf("Split(Merge(updates))[%d] = %q, want %q", i, got[i], updates[i])
+		}
+	}
+}
+
+func TestSplitEmpty(t *testing.T) {
+	if got := Split(nil); len(got) != 0 {
+		t.Fatalf("Split(nil) = %v, want empty", got)
+	}
+}
+
+func TestSplitTruncatedBlob(t *testing.T) {
+	blob := Merge([][]byte{[]byte("complete")})
+	truncated := blob[:len(blob)-2]
+
+	if got := Split(truncated); len(got) != 0 {
+		t.Fatalf("Split(truncated) = %v, want empty (incomplete trailing frame dropped)", got)
+	}
+}
@@ -0,0 +1,95 @@
+package crdt
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"time"
+)
+
+// Persister saves a project's compacted state back to Mongo, base64
+// encoded, as Project.Elements.
+type Persister interface {
+	PersistElements(ctx context.Context, projectID string, elementsBase64 string) error
+}
+
+// Store holds one Doc per project and periodically flushes dirty docs
+// through Persister, debounced so a burst of keystrokes doesn't trigger
+// a write per keystroke.
+type Store struct {
+	mu       sync.Mutex
+	docs     map[string]*Doc
+	persist  Persister
+	debounce time.Duration
+}
+
+// NewStore creates a Store that flushes dirty docs to persist every
+// debounce interval (~2s per the migration plan).
+func NewStore(persist Persister, debounce time.Duration) *Store {
+	return &Store{
+		docs:     make(map[string]*Doc),
+		persist:  persist,
+		debounce: debounce,
+	}
+}
+
+// Get returns the Doc for a project, loading it from elements (legacy
+// JSON, or a previously persisted base64 state) on first access.
+func (s *Store) Get(projectID string, elements string) *Doc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if doc, ok := s.docs[projectID]; ok {
+		return doc
+	}
+
+	var doc *Doc
+	switch {
+	case LooksLegacy(elements):
+		doc = FromLegacy(elements)
+	case elements != "":
+		seed, err := base64.StdEncoding.DecodeString(elements)
+		if err != nil {
+			seed = nil
+		}
+		doc = NewDoc(seed)
+	default:
+		doc = NewDoc(nil)
+	}
+
+	s.docs[projectID] = doc
+	return doc
+}
+
+// Run starts the background flusher; it blocks until ctx is cancelled,
+// so callers should launch it in its own goroutine.
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.debounce)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.flushDirty(ctx)
+		}
+	}
+}
+
+func (s *Store) flushDirty(ctx context.Context) {
+	s.mu.Lock()
+	docs := make(map[string]*Doc, len(s.docs))
+	for id, doc := range s.docs {
+		docs[id] = doc
+	}
+	s.mu.Unlock()
+
+	for projectID, doc := range docs {
+		state, ok := doc.FlushIfDirty()
+		if !ok {
+			continue
+		}
+		_ = s.persist.PersistElements(ctx, projectID, base64.StdEncoding.EncodeToString(state))
+	}
+}
@@ -0,0 +1,89 @@
+package crdt
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+)
+
+type fakePersister struct {
+	mu    sync.Mutex
+	saved map[string]string
+}
+
+func newFakePersister() *fakePersister {
+	return &fakePersister{saved: make(map[string]string)}
+}
+
+func (f *fakePersister) PersistElements(ctx context.Context, projectID string, elementsBase64 string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saved[projectID] = elementsBase64
+	return nil
+}
+
+func (f *fakePersister) get(projectID string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.saved[projectID]
+	return v, ok
+}
+
+func TestStoreGetCachesDocAcrossCalls(t *testing.T) {
+	s := NewStore(newFakePersister(), 0)
+
+	first := s.Get("project-1", "")
+	second := s.Get("project-1", "")
+	if first != second {
+		t.Fatal("Get() should return the same *Doc for the same projectID")
+	}
+}
+
+func TestStoreGetSeedsFromLegacyElements(t *testing.T) {
+	s := NewStore(newFakePersister(), 0)
+
+	doc := s.Get("project-1", `{"legacy":true}`)
+	got := Split(doc.State())
+	if len(got) != 1 || string(got[0]) != `{"legacy":true}` {
+		t.Fatalf("Get() with legacy elements seeded state = %v, want the legacy JSON as a single frame", got)
+	}
+}
+
+func TestStoreGetSeedsFromPersistedBase64(t *testing.T) {
+	s := NewStore(newFakePersister(), 0)
+
+	seeded := base64.StdEncoding.EncodeToString(Merge([][]byte{[]byte("prior")}))
+	doc := s.Get("project-1", seeded)
+	got := Split(doc.State())
+	if len(got) != 1 || string(got[0]) != "prior" {
+		t.Fatalf("Get() with persisted elements seeded state = %v, want [prior]", got)
+	}
+}
+
+func TestStoreFlushDirtyPersistsOnlyDirtyDocs(t *testing.T) {
+	persister := newFakePersister()
+	s := NewStore(persister, 0)
+
+	dirty := s.Get("dirty-project", "")
+	dirty.Apply([]byte("update"))
+	s.Get("clean-project", "")
+
+	s.flushDirty(context.Background())
+
+	if _, ok := persister.get("clean-project"); ok {
+		t.Fatal("flushDirty persisted a project with no changes since the last flush")
+	}
+
+	saved, ok := persister.get("dirty-project")
+	if !ok {
+		t.Fatal("flushDirty did not persist a project with a pending update")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(saved)
+	if err != nil {
+		t.Fatalf("persisted state is not valid base64: %v", err)
+	}
+	if got := Split(decoded); len(got) != 1 || string(got[0]) != "update" {
+		t.Fatalf("persisted state = %v, want [update]", got)
+	}
+}
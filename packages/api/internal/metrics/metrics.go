@@ -0,0 +1,39 @@
+// Package metrics holds the Prometheus collectors for collab-draw's
+// realtime subsystem, exposed by server.go on /metrics.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	// ProjectSubscribers is the number of editors currently subscribed
+	// to a project's live updates.
+	ProjectSubscribers = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "collab_project_subscribers",
+		Help: "Number of editors currently subscribed to a project.",
+	}, []string{"project_id"})
+
+	// BroadcastMessagesTotal counts project update messages successfully
+	// delivered to a subscriber.
+	BroadcastMessagesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "collab_broadcast_messages_total",
+		Help: "Total number of project update messages broadcast to subscribers.",
+	}, []string{"project_id"})
+
+	// BroadcastBytesTotal counts the serialized payload size of every
+	// delivered broadcast message.
+	BroadcastBytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "collab_broadcast_bytes_total",
+		Help: "Total number of payload bytes broadcast to subscribers.",
+	}, []string{"project_id"})
+
+	// BroadcastDroppedTotal counts messages dropped because a
+	// subscriber's channel was full or closed.
+	BroadcastDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "collab_broadcast_dropped_total",
+		Help: "Total number of broadcast messages dropped because a subscriber's channel was full or closed.",
+	}, []string{"project_id"})
+)
+
+func init() {
+	prometheus.MustRegister(ProjectSubscribers, BroadcastMessagesTotal, BroadcastBytesTotal, BroadcastDroppedTotal)
+}
@@ -10,7 +10,12 @@ type Project struct {
 	Members     []string       `bson:"members" json:"members"`
 	Workspace   *bson.ObjectID `bson:"workspace,omitempty" json:"workspace,omitempty"`
 	Personal    bool           `bson:"personal" json:"personal"`
-	Elements    string         `bson:"elements" json:"elements"`
-	CreatedAt   string         `bson:"created_at" json:"createdAt"`
-	UpdatedAt   string         `bson:"updated_at" json:"updatedAt"`
+	// Elements is a base64-encoded CRDT update log (see internal/crdt),
+	// maintained by the project's in-memory *crdt.Doc and flushed back
+	// here on a debounce. Projects created before the CRDT migration
+	// instead carry a raw JSON elements string; internal/crdt.LooksLegacy
+	// detects that case and wraps it into an initial Doc on load.
+	Elements  string `bson:"elements" json:"elements"`
+	CreatedAt string `bson:"created_at" json:"createdAt"`
+	UpdatedAt string `bson:"updated_at" json:"updatedAt"`
 }
@@ -0,0 +1,10 @@
+package models
+
+// WorkspaceAccess is a Workspace annotated with the looked-up (target)
+// user's permission role ("owner" or "member"). It backs the admin
+// GetAccessibleWorkspaces query, which merges what would otherwise be
+// two separate owner/member lookups into one result set.
+type WorkspaceAccess struct {
+	Workspace `bson:",inline"`
+	Role      string `bson:"role" json:"role"`
+}
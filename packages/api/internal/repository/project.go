@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chirag3003/collab-draw-backend/internal/config"
+	"github.com/chirag3003/collab-draw-backend/internal/db"
+	"github.com/chirag3003/collab-draw-backend/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type projectRepository struct {
+	projects *mongo.Collection
+}
+
+type ProjectRepository interface {
+	CreateProject(context context.Context, data *models.Project) error
+	GetProjectByID(context context.Context, id string, userID string) (*models.Project, error)
+	// PersistElements saves a project's compacted CRDT state vector
+	// (base64, see internal/crdt) back to Mongo. Called by the debounced
+	// flusher in internal/crdt.Store via graph/resolvers/crdt.go.
+	PersistElements(context context.Context, projectID string, elementsBase64 string) error
+}
+
+func NewProjectRepository() ProjectRepository {
+	return &projectRepository{
+		projects: db.GetCollection(config.PROJECT),
+	}
+}
+
+func (r *projectRepository) CreateProject(context context.Context, data *models.Project) error {
+	now := time.Now().Format(time.RFC3339)
+	data.CreatedAt = now
+	data.UpdatedAt = now
+
+	_, err := r.projects.InsertOne(context, data)
+	return err
+}
+
+func (r *projectRepository) GetProjectByID(context context.Context, id string, userID string) (*models.Project, error) {
+	ID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var project models.Project
+	err = r.projects.FindOne(context, bson.M{
+		"_id": ID,
+		"$or": bson.A{
+			bson.M{"owner": userID},
+			bson.M{"members": userID},
+		},
+	}).Decode(&project)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) PersistElements(context context.Context, projectID string, elementsBase64 string) error {
+	ID, err := bson.ObjectIDFromHex(projectID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.projects.UpdateOne(context, bson.M{"_id": ID}, bson.M{
+		"$set": bson.M{
+			"elements":   elementsBase64,
+			"updated_at": time.Now().Format(time.RFC3339),
+		},
+	})
+	return err
+}
@@ -1,18 +1,70 @@
 package repository
 
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/auth/clerk"
+	"github.com/chirag3003/collab-draw-backend/internal/auth/memory"
+	"github.com/chirag3003/collab-draw-backend/internal/auth/oidc"
+	"github.com/chirag3003/collab-draw-backend/internal/templates"
+)
+
 var repo *Repository
 
 type Repository struct {
 	Project   ProjectRepository
 	Workspace WorkspaceRepository
 	User      UserRepository
+	Auth      auth.Provider
+	Templates templates.TemplateRepository
 }
 
 func Setup() *Repository {
+	provider := newAuthProvider()
+
 	repo = &Repository{
 		Project:   NewProjectRepository(),
 		Workspace: NewWorkspaceRepository(),
-		User:      NewUserRepository(),
+		User:      NewUserRepository(provider),
+		Auth:      provider,
+		Templates: mustNewTemplateRepository(),
 	}
 	return repo
 }
+
+// mustNewTemplateRepository loads the embedded template library. A
+// failure here means the binary itself is malformed, so it's treated
+// the same as any other startup fatal error.
+func mustNewTemplateRepository() templates.TemplateRepository {
+	tr, err := templates.NewTemplateRepository()
+	if err != nil {
+		log.Fatalf("repository: load templates: %v", err)
+	}
+	return tr
+}
+
+// newAuthProvider picks the auth.Provider implementation from the
+// AUTH_PROVIDER env var (defaults to "clerk"). Self-hosters can set it
+// to "memory" for local development without a Clerk account, or to
+// "oidc" to plug in Dex, Keycloak, Auth0, or any other standard issuer
+// via OIDC_ISSUER_URL/OIDC_CLIENT_ID.
+func newAuthProvider() auth.Provider {
+	switch os.Getenv("AUTH_PROVIDER") {
+	case "memory":
+		return memory.New()
+	case "oidc":
+		provider, err := oidc.New(context.Background(), os.Getenv("OIDC_ISSUER_URL"), os.Getenv("OIDC_CLIENT_ID"))
+		if err != nil {
+			log.Fatalf("repository: init oidc provider: %v", err)
+		}
+		return provider
+	case "clerk", "":
+		return clerk.New()
+	default:
+		log.Fatalf("repository: unknown AUTH_PROVIDER %q", os.Getenv("AUTH_PROVIDER"))
+		return nil
+	}
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chirag3003/collab-draw-backend/internal/auth"
+	"github.com/chirag3003/collab-draw-backend/internal/auth/memory"
+)
+
+func TestUserRepositoryDelegatesToProvider(t *testing.T) {
+	provider := memory.New()
+	provider.AddUser(auth.User{ID: "user_1", Email: "a@example.com"})
+
+	repo := NewUserRepository(provider)
+
+	users, err := repo.GetUsersByID(context.Background(), []string{"user_1"})
+	if err != nil {
+		t.Fatalf("GetUsersByID returned error: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != "user_1" {
+		t.Fatalf("unexpected users: %+v", users)
+	}
+
+	byEmail, err := repo.GetUserByEmail(context.Background(), "a@example.com")
+	if err != nil {
+		t.Fatalf("GetUserByEmail returned error: %v", err)
+	}
+	if len(byEmail) != 1 || byEmail[0].Email != "a@example.com" {
+		t.Fatalf("unexpected users: %+v", byEmail)
+	}
+}
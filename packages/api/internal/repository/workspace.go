@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"log"
 	"time"
 
 	"github.com/chirag3003/collab-draw-backend/internal/config"
@@ -23,6 +24,7 @@ type WorkspaceRepository interface {
 	GetWorkspaceByID(context context.Context, id string, userID string) (*models.Workspace, error)
 	GetWorkspacesByUser(context context.Context, userID string) (*[]models.Workspace, error)
 	GetSharedWorkspaces(context context.Context, userID string) (*[]models.Workspace, error)
+	GetAccessibleWorkspaces(context context.Context, targetUserID string, callerUserID string) ([]models.WorkspaceAccess, error)
 	UpdateWorkspaceMetadata(context context.Context, id string, name string, description string, userID string) error
 	DeleteWorkspace(context context.Context, id string, userID string) error
 	AddMemberToWorkspace(context context.Context, workspaceID string, userID string) error
@@ -102,6 +104,49 @@ func (r *workspaceRepository) GetSharedWorkspaces(context context.Context, userI
 	return &workspaces, nil
 }
 
+// GetAccessibleWorkspaces returns every workspace targetUserID can
+// access, owned or shared, as a single merged result with a role field
+// per row ("owner" / "member") instead of requiring the caller to union
+// GetWorkspacesByUser and GetSharedWorkspaces client-side. callerUserID
+// is not used to filter the result; it's logged as an audit trail of
+// which admin looked up which user's workspaces.
+func (r *workspaceRepository) GetAccessibleWorkspaces(context context.Context, targetUserID string, callerUserID string) ([]models.WorkspaceAccess, error) {
+	log.Printf("audit: admin %s viewed accessible workspaces for user %s", callerUserID, targetUserID)
+
+	pipeline := bson.A{
+		bson.M{
+			"$match": bson.M{
+				"$or": bson.A{
+					bson.M{"owner_id": targetUserID},
+					bson.M{"members": targetUserID},
+				},
+			},
+		},
+		bson.M{
+			"$addFields": bson.M{
+				"role": bson.M{
+					"$cond": bson.M{
+						"if":   bson.M{"$eq": bson.A{"$owner_id", targetUserID}},
+						"then": "owner",
+						"else": "member",
+					},
+				},
+			},
+		},
+	}
+
+	cursor, err := r.workspace.Aggregate(context, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaces []models.WorkspaceAccess
+	if err = cursor.All(context, &workspaces); err != nil {
+		return nil, err
+	}
+	return workspaces, nil
+}
+
 func (r *workspaceRepository) UpdateWorkspaceMetadata(context context.Context, id string, name string, description string, userID string) error {
 	ID, err := bson.ObjectIDFromHex(id)
 	if err != nil {
@@ -0,0 +1,80 @@
+// Package templates embeds a curated library of starting-point Excalidraw
+// boards so new projects don't have to begin on an empty canvas.
+package templates
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+//go:embed examples/*.json
+var examplesFS embed.FS
+
+// Template is a named, categorized bundle of Excalidraw-compatible
+// elements that CreateProject can seed a new Project.Elements from.
+type Template struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+	Category     string `json:"category"`
+	Elements     string `json:"elements"`
+}
+
+// TemplateRepository exposes the curated, in-repo template library.
+type TemplateRepository interface {
+	List(ctx context.Context) ([]Template, error)
+	Get(ctx context.Context, id string) (Template, error)
+}
+
+type templateRepository struct {
+	templates map[string]Template
+	order     []string
+}
+
+// NewTemplateRepository loads every template embedded under examples/ at
+// build time.
+func NewTemplateRepository() (TemplateRepository, error) {
+	entries, err := examplesFS.ReadDir("examples")
+	if err != nil {
+		return nil, fmt.Errorf("templates: read embedded examples: %w", err)
+	}
+
+	repo := &templateRepository{templates: make(map[string]Template, len(entries))}
+	for _, entry := range entries {
+		raw, err := examplesFS.ReadFile("examples/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("templates: read %s: %w", entry.Name(), err)
+		}
+
+		var tpl Template
+		if err := json.Unmarshal(raw, &tpl); err != nil {
+			return nil, fmt.Errorf("templates: parse %s: %w", entry.Name(), err)
+		}
+
+		repo.templates[tpl.ID] = tpl
+		repo.order = append(repo.order, tpl.ID)
+	}
+	sort.Strings(repo.order)
+
+	return repo, nil
+}
+
+func (r *templateRepository) List(ctx context.Context) ([]Template, error) {
+	list := make([]Template, 0, len(r.order))
+	for _, id := range r.order {
+		list = append(list, r.templates[id])
+	}
+	return list, nil
+}
+
+func (r *templateRepository) Get(ctx context.Context, id string) (Template, error) {
+	tpl, ok := r.templates[id]
+	if !ok {
+		return Template{}, fmt.Errorf("templates: unknown template %q", id)
+	}
+	return tpl, nil
+}
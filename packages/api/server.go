@@ -17,11 +17,10 @@ import (
 	"github.com/chirag3003/collab-draw-backend/internal/auth"
 	"github.com/chirag3003/collab-draw-backend/internal/db"
 	"github.com/chirag3003/collab-draw-backend/internal/repository"
-	"github.com/clerk/clerk-sdk-go/v2"
-	clerkHttp "github.com/clerk/clerk-sdk-go/v2/http"
 	"github.com/go-chi/chi"
 	"github.com/gorilla/websocket"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -46,10 +45,10 @@ func main() {
 	// Setting up repositories
 	repo := repository.Setup()
 
-	//setting up Clerk
-	clerk.SetKey(os.Getenv("CLERK_SECRET_KEY"))
+	resolver := resolvers.NewResolver(repo)
+	go resolver.StartBackgroundWork(context.Background())
 
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolvers.NewResolver(repo)}))
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
 
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
@@ -58,39 +57,17 @@ func main() {
 			authHeader := initPayload.Authorization()
 			if authHeader == "" {
 				// Try to get from other params
-				if auth, ok := initPayload["authorization"].(string); ok {
-					authHeader = auth
+				if a, ok := initPayload["authorization"].(string); ok {
+					authHeader = a
 				}
 			}
 
-			//log.Printf("WebSocket InitFunc - Auth header: %v", authHeader != "")
-
-			// If we have authorization, validate it
+			// If we have authorization, validate it against whichever
+			// auth.Provider was wired into the repository.
 			if authHeader != "" {
-				// Create a fake request to validate the token
-				req, _ := http.NewRequest("GET", "/", nil)
-				req.Header.Set("Authorization", authHeader)
-
-				// Use Clerk to verify the session
-				clerkClient := clerkHttp.RequireHeaderAuthorization()
-				var validatedCtx context.Context
-				var authOk bool
-
-				// Create a test handler to capture the context
-				testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					claims, ok := clerk.SessionClaimsFromContext(r.Context())
-					if ok {
-						validatedCtx = context.WithValue(ctx, auth.UserContextKey, claims)
-						authOk = true
-						//log.Printf("WebSocket auth successful for user: %v", claims.Subject)
-					}
-				})
-
-				// Wrap with Clerk validation
-				clerkClient(testHandler).ServeHTTP(nil, req.WithContext(ctx))
-
-				if authOk {
-					return validatedCtx, &initPayload, nil
+				claims, err := repo.Auth.VerifyToken(ctx, authHeader)
+				if err == nil {
+					return context.WithValue(ctx, auth.UserContextKey, claims), &initPayload, nil
 				}
 			}
 
@@ -135,6 +112,7 @@ func main() {
 		AllowCredentials: true,
 	}).Handler)
 	router.Handle("/", playground.Handler("GraphQL playground", "/query"))
+	router.Handle("/metrics", promhttp.Handler())
 
 	// Custom middleware that allows WebSocket upgrades to bypass auth middleware
 	router.Handle("/query", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -145,7 +123,7 @@ func main() {
 			return
 		}
 		// For regular HTTP requests, use auth middleware
-		auth.Middleware()(srv).ServeHTTP(w, r)
+		auth.Middleware(repo.Auth)(srv).ServeHTTP(w, r)
 	}))
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)